@@ -0,0 +1,144 @@
+package batcherror
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Collector accumulates BatchError values from multiple goroutines
+// processing a batch in parallel. It is safe for concurrent use by
+// multiple goroutines, unlike building up the joined error by hand with
+// errors.Join.
+//
+// Typical usage, spreading the work over a bounded number of workers:
+//
+//	c := batcherror.NewCollector(ctx, batcherror.WithConcurrency(8))
+//	for idx, item := range items {
+//		idx, item := idx, item
+//		c.GoIdx(idx, func(ctx context.Context) error {
+//			return process(item)
+//		})
+//	}
+//	if err := c.Wait(); err != nil {
+//		// handle joined error, e.g. with AtIdx/MapIndexedErrors
+//	}
+type Collector struct {
+	mu  sync.Mutex
+	err error
+
+	failFast bool
+
+	sem    chan struct{}
+	wg     sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	once sync.Once
+}
+
+// NewCollector creates a Collector ready for concurrent use.
+func NewCollector(ctx context.Context, opts ...Option) *Collector {
+	return newCollector(ctx, newConfig(opts))
+}
+
+func newCollector(ctx context.Context, cfg *config) *Collector {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	c := &Collector{failFast: cfg.failFast}
+	c.ctx, c.cancel = context.WithCancel(ctx)
+	if cfg.concurrency > 0 {
+		c.sem = make(chan struct{}, cfg.concurrency)
+	}
+
+	return c
+}
+
+// Add records err at idx if err is non-nil. It is safe to call from
+// multiple goroutines.
+func (c *Collector) Add(idx int, err error) {
+	if err == nil {
+		return
+	}
+	c.AddRaw(New(err, idx))
+}
+
+// AddRaw joins err into the collected result as-is, without wrapping it
+// in a BatchError. It is safe to call from multiple goroutines.
+func (c *Collector) AddRaw(err error) {
+	if err == nil {
+		return
+	}
+
+	c.mu.Lock()
+	c.err = errors.Join(c.err, err)
+	c.mu.Unlock()
+
+	if c.failFast {
+		c.abort()
+	}
+}
+
+// abort cancels the Collector's context, safe to call more than once or
+// concurrently.
+func (c *Collector) abort() {
+	c.once.Do(c.cancel)
+}
+
+// Go runs fn in a new goroutine, blocking until a concurrency slot is
+// available if the Collector was created with WithConcurrency. Any error
+// returned by fn is recorded as-is via AddRaw; Go does not assign it an
+// index. Use GoIdx, or wrap the error yourself with New(err, idx) before
+// returning it, to associate it with a batch index.
+func (c *Collector) Go(fn func(ctx context.Context) error) {
+	if c.sem != nil {
+		c.sem <- struct{}{}
+	}
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		if c.sem != nil {
+			defer func() { <-c.sem }()
+		}
+		c.AddRaw(fn(c.ctx))
+	}()
+}
+
+// GoIdx is like Go, but records any returned error at the given idx via
+// Add instead of appending it raw.
+func (c *Collector) GoIdx(idx int, fn func(ctx context.Context) error) {
+	c.Go(func(ctx context.Context) error {
+		if err := fn(ctx); err != nil {
+			return New(err, idx)
+		}
+		return nil
+	})
+}
+
+// Context returns the Collector's context. It is cancelled as soon as the
+// first error is recorded when the Collector was created with
+// WithFailFast.
+func (c *Collector) Context() context.Context {
+	return c.ctx
+}
+
+// Wait blocks until all goroutines started with Go/GoIdx have returned,
+// then returns the joined result, equivalent to calling Err() after the
+// fact.
+func (c *Collector) Wait() error {
+	c.wg.Wait()
+	c.cancel()
+	return c.Err()
+}
+
+// Err returns the joined result of all errors recorded so far, compatible
+// with UnwrapJoinedErrors, AtIdx and MapIndexedErrors. It can be called
+// before Wait to inspect errors recorded so far.
+func (c *Collector) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
@@ -0,0 +1,47 @@
+package batcherror
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+	items := []bool{false, true, true, false, true} // should_fail
+
+	c := NewCollector(context.Background(), WithConcurrency(2))
+	for idx, fail := range items {
+		idx, fail := idx, fail
+		c.Go(func(ctx context.Context) error {
+			if fail {
+				return New(errors.New("failure"), idx)
+			}
+			return nil
+		})
+	}
+
+	err := c.Wait()
+	require.Error(t, err)
+
+	m := MapIndexedErrors(err)
+	require.Len(t, m, 3)
+	for idx, fail := range items {
+		_, failed := m[idx]
+		require.Equal(t, fail, failed, "item [%v]", idx)
+	}
+}
+
+func TestCollectorFailFast(t *testing.T) {
+	c := NewCollector(context.Background(), WithFailFast())
+	c.Add(0, errors.New("boom"))
+
+	select {
+	case <-c.Context().Done():
+	default:
+		t.Fatal("expected context to be cancelled after first error")
+	}
+
+	require.Error(t, c.Wait())
+}
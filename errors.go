@@ -12,6 +12,13 @@ type multiErr interface {
 	Unwrap() []error
 }
 
+// errorsMultiErr matches the older multi-error convention used by
+// hashicorp/go-multierror, uber-go/multierr and k8s.io/apimachinery's
+// Aggregate, which predates the standard library's Unwrap() []error.
+type errorsMultiErr interface {
+	Errors() []error
+}
+
 // BatchError is a special error type that can be used to allow individual
 // failures in batch operations without failing the full batch.
 // BatchError references the index in the batch where the error occurred,
@@ -37,8 +44,9 @@ type multiErr interface {
 //			}
 //		}
 type BatchError struct {
-	idx int
-	err error
+	idx      int
+	err      error
+	severity Severity
 }
 
 func New(err error, idx int) *BatchError {
@@ -62,18 +70,21 @@ func (b *BatchError) Idx() int {
 
 // AtIdx returns an error if the provided err is a joinError type and
 // any of the joined errors is a BatchError that is at the specified idx.
+// The returned error, if any, is a *JoinedBatchError, rendering the
+// matched BatchErrors sorted by index.
 func AtIdx(err error, idx int) error {
-	var match error
+	var matches []error
 	collect := func(e error) {
 		be := new(BatchError)
-		if errors.As(e, &be) {
-			if be.Idx() == idx {
-				match = errors.Join(match, be)
-			}
+		if errors.As(e, &be) && be.Idx() == idx {
+			matches = append(matches, e)
 		}
 	}
 	traverseJoinedErrors(err, collect)
-	return match
+	if len(matches) == 0 {
+		return nil
+	}
+	return JoinBatch(matches...)
 }
 
 // MapIndexedErrors returns a map where the batch errors are mapped
@@ -90,6 +101,21 @@ func MapIndexedErrors(err error) map[int]error {
 	return m
 }
 
+// MapIndexedErrorLists is like MapIndexedErrors, but keeps every BatchError
+// found at a given index instead of letting later ones overwrite earlier
+// ones, since multiple errors can share an index.
+func MapIndexedErrorLists(err error) map[int][]error {
+	m := map[int][]error{}
+	collect := func(e error) {
+		be := new(BatchError)
+		if errors.As(e, &be) {
+			m[be.Idx()] = append(m[be.Idx()], e)
+		}
+	}
+	traverseJoinedErrors(err, collect)
+	return m
+}
+
 // UnwrapJoinedErrors returns the slice of errors that is the result of using errors.Join
 // If err does not implement MultiErr then it is returned as the single item in the slice.
 func UnwrapJoinedErrors(err error) []error {
@@ -103,15 +129,21 @@ func UnwrapJoinedErrors(err error) []error {
 }
 
 // traverseJoinedErrors traverses the tree of wrapped errors (DFS) and collect them
-// using the provided function.
+// using the provided function. In addition to errors.Join's Unwrap() []error,
+// it also recognizes the older Errors() []error convention, so trees built
+// with foreign multi-error types interoperate transparently.
 func traverseJoinedErrors(err error, collect func(error)) {
-	e, ok := err.(multiErr)
-	if !ok {
+	var errs []error
+	switch e := err.(type) {
+	case multiErr:
+		errs = e.Unwrap()
+	case errorsMultiErr:
+		errs = e.Errors()
+	default:
 		collect(err)
 		return
 	}
 
-	errs := e.Unwrap()
 	if len(errs) == 1 {
 		collect(err)
 		return
@@ -137,7 +169,7 @@ func Short(err error, maxMessages int) error {
 		msg = errors.Join(errs...).Error()
 	}
 
-	if errors.Is(err, ErrBatchFailed) {
+	if IsBatchFailed(err) {
 		msg = strings.Join([]string{"batch failed:", msg}, " ")
 	}
 
@@ -0,0 +1,212 @@
+package batcherror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Severity classifies how serious a BatchError is, letting callers decide
+// whether to continue processing the rest of the batch or abort it
+// declaratively instead of by counting errors.
+type Severity int
+
+const (
+	// SeverityError is the default severity for a BatchError created with
+	// New: the item failed, but the batch as a whole can continue.
+	SeverityError Severity = iota
+	// SeverityWarn marks an error that should be surfaced but does not
+	// count as an item failure.
+	SeverityWarn
+	// SeverityFatal marks an error that should cause the batch to stop,
+	// see HasFatal.
+	SeverityFatal
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarn:
+		return "warn"
+	case SeverityFatal:
+		return "fatal"
+	default:
+		return "error"
+	}
+}
+
+// WithSeverity is like New, but attaches an explicit Severity to the
+// resulting BatchError instead of the default SeverityError.
+func WithSeverity(err error, idx int, severity Severity) *BatchError {
+	return &BatchError{
+		idx:      idx,
+		err:      err,
+		severity: severity,
+	}
+}
+
+// Severity returns the BatchError's severity.
+func (b *BatchError) Severity() Severity {
+	return b.severity
+}
+
+// HasFatal reports whether err, or any error joined into it, is a
+// BatchError with SeverityFatal.
+func HasFatal(err error) bool {
+	fatal := false
+	collect := func(e error) {
+		be := new(BatchError)
+		if errors.As(e, &be) && be.Severity() == SeverityFatal {
+			fatal = true
+		}
+	}
+	traverseJoinedErrors(err, collect)
+	return fatal
+}
+
+// Format implements fmt.Formatter. %v renders the same one-line form as
+// Error, while %+v renders an indented tree via FormatTree.
+func (b *BatchError) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		io.WriteString(f, FormatTree(b))
+		return
+	}
+	io.WriteString(f, b.Error())
+}
+
+// MarshalJSON implements json.Marshaler, encoding b as
+// {"index": N, "error": "...", "causes": [...]}, recursively encoding any
+// joined errors wrapped by b under "causes".
+func (b *BatchError) MarshalJSON() ([]byte, error) {
+	var causes []json.RawMessage
+	for _, e := range joinedBranches(b.err) {
+		data, err := marshalError(e)
+		if err != nil {
+			return nil, err
+		}
+		causes = append(causes, data)
+	}
+
+	return json.Marshal(struct {
+		Index  int               `json:"index"`
+		Error  string            `json:"error"`
+		Causes []json.RawMessage `json:"causes,omitempty"`
+	}{
+		Index:  b.idx,
+		Error:  b.err.Error(),
+		Causes: causes,
+	})
+}
+
+// marshalError encodes err as JSON, deferring to its own MarshalJSON if it
+// implements json.Marshaler (as *BatchError does), recursing into joined
+// children otherwise, and falling back to its message as a plain string.
+func marshalError(err error) (json.RawMessage, error) {
+	if m, ok := err.(json.Marshaler); ok {
+		return m.MarshalJSON()
+	}
+
+	if errs := joinedBranches(err); len(errs) > 1 {
+		causes := make([]json.RawMessage, 0, len(errs))
+		for _, e := range errs {
+			data, err := marshalError(e)
+			if err != nil {
+				return nil, err
+			}
+			causes = append(causes, data)
+		}
+		return json.Marshal(struct {
+			Causes []json.RawMessage `json:"causes"`
+		}{Causes: causes})
+	}
+
+	return json.Marshal(err.Error())
+}
+
+// FormatTree renders err, or any error produced by errors.Join, as an
+// indented tree grouped by BatchError index, for human-readable logging.
+// A BatchError whose own wrapped error is itself a joined tree (e.g. one
+// produced by Flatten or nested batching) is expanded into nested groups
+// rather than printed as a single, already multi-line leaf.
+func FormatTree(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	writeTree(&b, err, 0)
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// writeTree groups err's leaves by BatchError index and writes them to b,
+// indented by depth, recursing into any leaf whose wrapped error is
+// itself a joined tree.
+func writeTree(b *strings.Builder, err error, depth int) {
+	groups := map[int][]error{}
+	var order []int
+	var ungrouped []error
+
+	for _, e := range UnwrapJoinedErrors(err) {
+		be := new(BatchError)
+		if errors.As(e, &be) {
+			idx := be.Idx()
+			if _, ok := groups[idx]; !ok {
+				order = append(order, idx)
+			}
+			groups[idx] = append(groups[idx], e)
+			continue
+		}
+		ungrouped = append(ungrouped, e)
+	}
+	sort.Ints(order)
+
+	indent := strings.Repeat("  ", depth)
+	for _, idx := range order {
+		fmt.Fprintf(b, "%s[%d]:\n", indent, idx)
+		for _, e := range groups[idx] {
+			writeLeaf(b, e, depth+1)
+		}
+	}
+	if len(ungrouped) > 0 {
+		fmt.Fprintf(b, "%s(unindexed):\n", indent)
+		for _, e := range ungrouped {
+			writeLeaf(b, e, depth+1)
+		}
+	}
+}
+
+// writeLeaf writes a single grouped leaf, indented by depth. If the leaf
+// is a BatchError wrapping a joined tree of its own, it recurses via
+// writeTree instead of printing the leaf's (already multi-line) message.
+func writeLeaf(b *strings.Builder, err error, depth int) {
+	be := new(BatchError)
+	if errors.As(err, &be) && isJoinedTree(be.Unwrap()) {
+		writeTree(b, be.Unwrap(), depth)
+		return
+	}
+
+	fmt.Fprintf(b, "%s%s\n", strings.Repeat("  ", depth), err.Error())
+}
+
+// isJoinedTree reports whether err is itself a multi-error with more than
+// one branch.
+func isJoinedTree(err error) bool {
+	return len(joinedBranches(err)) > 1
+}
+
+// joinedBranches returns err's joined errors, recognizing both
+// errors.Join's Unwrap() []error and the older Errors() []error
+// convention used by foreign multi-error types. It returns nil if err is
+// neither.
+func joinedBranches(err error) []error {
+	switch e := err.(type) {
+	case multiErr:
+		return e.Unwrap()
+	case errorsMultiErr:
+		return e.Errors()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,52 @@
+package batcherror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	be := New(errors.New("failure"), 0)
+
+	require.Equal(t, "failure at [0]", fmt.Sprintf("%v", be))
+	require.Equal(t, "[0]:\n  failure at [0]", fmt.Sprintf("%+v", be))
+}
+
+func TestBatchErrorMarshalJSON(t *testing.T) {
+	be := New(errors.New("failure"), 2)
+	data, err := json.Marshal(be)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"index": 2, "error": "failure"}`, string(data))
+}
+
+func TestFormatTreeNestedBatchError(t *testing.T) {
+	nested := errors.Join(New(errors.New("a"), 0), New(errors.New("b"), 1))
+	err := New(nested, 5)
+
+	require.Equal(t,
+		"[5]:\n  [0]:\n    a at [0]\n  [1]:\n    b at [1]",
+		fmt.Sprintf("%+v", err),
+	)
+}
+
+func TestBatchErrorMarshalJSONForeignMultiError(t *testing.T) {
+	foreign := &fakeMultiError{errs: []error{New(errors.New("a"), 0), New(errors.New("b"), 1)}}
+	be := New(foreign, 5)
+
+	data, err := json.Marshal(be)
+	require.NoError(t, err)
+	require.JSONEq(t,
+		`{"index": 5, "error": "a at [0]\nb at [1]", "causes": [{"index": 0, "error": "a"}, {"index": 1, "error": "b"}]}`,
+		string(data),
+	)
+}
+
+func TestHasFatal(t *testing.T) {
+	err := errors.Join(New(errors.New("failure"), 0), WithSeverity(errors.New("oops"), 1, SeverityFatal))
+	require.True(t, HasFatal(err))
+	require.False(t, HasFatal(New(errors.New("failure"), 0)))
+}
@@ -0,0 +1,65 @@
+package batcherror
+
+import "errors"
+
+// BatchSize returns the total number of leaf errors in err, i.e. the
+// length of UnwrapJoinedErrors(err). It returns 0 for a nil err.
+func BatchSize(err error) int {
+	if err == nil {
+		return 0
+	}
+	return len(UnwrapJoinedErrors(err))
+}
+
+// Filter returns a new joined error containing only the leaves of err for
+// which keep returns true, or nil if none do. err may be produced by
+// errors.Join or by any foreign multi-error type recognized by
+// traverseJoinedErrors.
+func Filter(err error, keep func(error) bool) error {
+	if err == nil {
+		return nil
+	}
+
+	var kept []error
+	for _, e := range UnwrapJoinedErrors(err) {
+		if keep(e) {
+			kept = append(kept, e)
+		}
+	}
+
+	if len(kept) == 0 {
+		return nil
+	}
+	return errors.Join(kept...)
+}
+
+// Flatten collapses any nested tree of joined errors in err into a single
+// flat join, deduplicating identical BatchError entries at the same
+// index.
+func Flatten(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	seen := map[int]map[string]bool{}
+	var flat []error
+	for _, e := range UnwrapJoinedErrors(err) {
+		be := new(BatchError)
+		if errors.As(e, &be) {
+			msg := be.Error()
+			if seen[be.Idx()] == nil {
+				seen[be.Idx()] = map[string]bool{}
+			}
+			if seen[be.Idx()][msg] {
+				continue
+			}
+			seen[be.Idx()][msg] = true
+		}
+		flat = append(flat, e)
+	}
+
+	if len(flat) == 0 {
+		return nil
+	}
+	return errors.Join(flat...)
+}
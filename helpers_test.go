@@ -0,0 +1,52 @@
+package batcherror
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMultiError mimics the Errors() []error convention used by
+// hashicorp/go-multierror, uber-go/multierr and k8s Aggregate.
+type fakeMultiError struct {
+	errs []error
+}
+
+func (f *fakeMultiError) Error() string   { return errors.Join(f.errs...).Error() }
+func (f *fakeMultiError) Errors() []error { return f.errs }
+
+func TestBatchSize(t *testing.T) {
+	require.Equal(t, 0, BatchSize(nil))
+
+	err := errors.Join(New(errors.New("a"), 0), New(errors.New("b"), 1))
+	require.Equal(t, 2, BatchSize(err))
+}
+
+func TestFilter(t *testing.T) {
+	err := errors.Join(New(errors.New("a"), 0), New(errors.New("b"), 1), errors.New("c"))
+
+	kept := Filter(err, func(e error) bool {
+		be := new(BatchError)
+		return errors.As(e, &be)
+	})
+	require.Equal(t, 2, BatchSize(kept))
+
+	require.Nil(t, Filter(err, func(error) bool { return false }))
+}
+
+func TestFlatten(t *testing.T) {
+	dup := New(errors.New("dup"), 0)
+	nested := errors.Join(dup, errors.Join(dup, New(errors.New("other"), 1)))
+
+	flat := Flatten(nested)
+	require.Equal(t, 2, BatchSize(flat))
+}
+
+func TestTraverseJoinedErrorsForeignType(t *testing.T) {
+	foreign := &fakeMultiError{errs: []error{New(errors.New("a"), 0), New(errors.New("b"), 1)}}
+
+	m := MapIndexedErrors(foreign)
+	require.Len(t, m, 2)
+	require.NotNil(t, AtIdx(foreign, 0))
+}
@@ -0,0 +1,84 @@
+package batcherror
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+)
+
+// JoinedBatchError is a concrete multi-error type returned by JoinBatch
+// and AtIdx. Unlike a plain errors.Join result, it renders its errors
+// sorted by BatchError index. It only needs to implement Unwrap() []error
+// itself - errors.Is/errors.As already recurse through that (and through
+// BatchError's own Unwrap() error) since Go 1.20, so no custom Is/As is
+// needed for sentinels or types wrapped at any depth to be found.
+type JoinedBatchError struct {
+	errs []error
+}
+
+// JoinBatch joins errs the same way errors.Join does, but returns a
+// *JoinedBatchError: nil errors are dropped, nil is returned if nothing
+// is left, and the result renders with BatchError entries sorted by
+// index (entries without a BatchError idx keep their relative order,
+// after any indexed ones).
+func JoinBatch(errs ...error) error {
+	var nonNil []error
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+	if len(nonNil) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(nonNil, func(i, j int) bool {
+		return batchIdxOf(nonNil[i]) < batchIdxOf(nonNil[j])
+	})
+
+	return &JoinedBatchError{errs: nonNil}
+}
+
+// batchIdxOf returns err's BatchError index, or math.MaxInt if err is not
+// a BatchError, so un-indexed errors sort last.
+func batchIdxOf(err error) int {
+	be := new(BatchError)
+	if errors.As(err, &be) {
+		return be.Idx()
+	}
+	return math.MaxInt
+}
+
+func (j *JoinedBatchError) Error() string {
+	lines := make([]string, len(j.errs))
+	for i, err := range j.errs {
+		lines[i] = err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap returns the joined errors, making JoinedBatchError compatible
+// with errors.Is/errors.As and traverseJoinedErrors.
+func (j *JoinedBatchError) Unwrap() []error {
+	return j.errs
+}
+
+// IsBatchFailed reports whether err, or any error joined into it at any
+// depth, is ErrBatchFailed. It walks the tree the same way HasFatal does,
+// rather than relying on errors.Is(err, ErrBatchFailed) directly, to keep
+// batch-failure detection self-contained in one helper alongside HasFatal.
+func IsBatchFailed(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	found := false
+	collect := func(e error) {
+		if errors.Is(e, ErrBatchFailed) {
+			found = true
+		}
+	}
+	traverseJoinedErrors(err, collect)
+	return found
+}
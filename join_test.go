@@ -0,0 +1,63 @@
+package batcherror
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoinBatch(t *testing.T) {
+	require.Nil(t, JoinBatch(nil, nil))
+
+	joined := JoinBatch(New(errors.New("b"), 2), New(errors.New("a"), 0), errors.New("unindexed"))
+	require.Equal(t, "a at [0]\nb at [2]\nunindexed", joined.Error())
+
+	var jbe *JoinedBatchError
+	require.True(t, errors.As(joined, &jbe))
+}
+
+func TestJoinBatchIsAs(t *testing.T) {
+	joined := JoinBatch(New(ErrBatchFailed, 0), New(errors.New("other"), 1))
+
+	require.True(t, errors.Is(joined, ErrBatchFailed))
+
+	var be *BatchError
+	require.True(t, errors.As(joined, &be))
+	require.Equal(t, 0, be.Idx())
+}
+
+func TestAtIdxReturnsJoinedBatchError(t *testing.T) {
+	err := errors.Join(
+		New(errors.New("first"), 0),
+		New(errors.New("second"), 0),
+	)
+
+	got := AtIdx(err, 0)
+	var jbe *JoinedBatchError
+	require.True(t, errors.As(got, &jbe))
+	require.Equal(t, "first at [0]\nsecond at [0]", got.Error())
+
+	require.Nil(t, AtIdx(err, 9))
+}
+
+func TestIsBatchFailed(t *testing.T) {
+	require.False(t, IsBatchFailed(nil))
+	require.False(t, IsBatchFailed(errors.New("plain")))
+
+	err := errors.Join(New(errors.New("a"), 0), New(ErrBatchFailed, 1))
+	require.True(t, IsBatchFailed(err))
+}
+
+func TestMapIndexedErrorLists(t *testing.T) {
+	err := errors.Join(
+		New(errors.New("first"), 0),
+		New(errors.New("second"), 0),
+		New(errors.New("other"), 1),
+	)
+
+	m := MapIndexedErrorLists(err)
+	require.Len(t, m, 2)
+	require.Len(t, m[0], 2)
+	require.Len(t, m[1], 1)
+}
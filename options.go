@@ -0,0 +1,71 @@
+package batcherror
+
+import "time"
+
+// config holds the settings shared by Collector and Run, configured
+// through Option.
+type config struct {
+	concurrency int
+	failFast    bool
+	maxFailures int
+	attempts    int
+	backoff     func(attempt int) time.Duration
+	classifier  func(error) bool
+}
+
+// Option configures a Collector or a Run call.
+type Option func(*config)
+
+// WithConcurrency limits the number of goroutines running at once to n.
+// A value <= 0 means unlimited.
+func WithConcurrency(n int) Option {
+	return func(c *config) {
+		c.concurrency = n
+	}
+}
+
+// WithFailFast cancels the context passed to in-flight work as soon as
+// the first error is recorded. For Run, the returned aggregate error is
+// additionally wrapped with ErrBatchFailed.
+func WithFailFast() Option {
+	return func(c *config) {
+		c.failFast = true
+	}
+}
+
+// WithMaxFailures aborts a Run call once n items have failed, leaving
+// zero values in the result slice for any item that didn't get to run.
+func WithMaxFailures(n int) Option {
+	return func(c *config) {
+		c.maxFailures = n
+	}
+}
+
+// WithRetry retries a failing item up to attempts times (including the
+// first try), waiting backoff(attempt) between tries. Only the failing
+// indices are retried; the final error is wrapped so AtIdx still works.
+func WithRetry(attempts int, backoff func(attempt int) time.Duration) Option {
+	return func(c *config) {
+		c.attempts = attempts
+		c.backoff = backoff
+	}
+}
+
+// WithClassifier marks an error as retryable when classify returns true.
+// Without a classifier, every error is considered retryable.
+func WithClassifier(classify func(error) bool) Option {
+	return func(c *config) {
+		c.classifier = classify
+	}
+}
+
+func newConfig(opts []Option) *config {
+	cfg := &config{attempts: 1}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.attempts < 1 {
+		cfg.attempts = 1
+	}
+	return cfg
+}
@@ -0,0 +1,83 @@
+package batcherror
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// Run processes items concurrently by calling fn for each, collecting
+// per-item failures as BatchError values and returning them joined,
+// compatible with the rest of the package (AtIdx, MapIndexedErrors, ...).
+//
+// The returned slice always has len(items); indices for items that failed
+// or never ran hold the zero value of R.
+func Run[T any, R any](ctx context.Context, items []T, fn func(ctx context.Context, idx int, item T) (R, error), opts ...Option) ([]R, error) {
+	cfg := newConfig(opts)
+	results := make([]R, len(items))
+	col := newCollector(ctx, cfg)
+
+	var failures int32
+	for idx, item := range items {
+		idx, item := idx, item
+		col.Go(func(ctx context.Context) error {
+			res, err := runWithRetry(ctx, cfg, idx, item, fn)
+			if err == nil {
+				results[idx] = res
+				return nil
+			}
+
+			if cfg.maxFailures > 0 && int(atomic.AddInt32(&failures, 1)) >= cfg.maxFailures {
+				col.abort()
+			}
+			return New(err, idx)
+		})
+	}
+
+	err := col.Wait()
+	if err != nil && cfg.failFast {
+		err = errors.Join(err, ErrBatchFailed)
+	}
+	return results, err
+}
+
+// runWithRetry calls fn for item, up to cfg.attempts times in total
+// (including the first try) while the error is retryable according to
+// cfg.classifier, waiting
+// cfg.backoff(attempt) between tries.
+func runWithRetry[T any, R any](ctx context.Context, cfg *config, idx int, item T, fn func(ctx context.Context, idx int, item T) (R, error)) (R, error) {
+	var lastErr error
+	for attempt := 0; attempt < cfg.attempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			var zero R
+			return zero, err
+		}
+
+		res, err := fn(ctx, idx, item)
+		if err == nil {
+			return res, nil
+		}
+		lastErr = err
+
+		if attempt == cfg.attempts-1 || (cfg.classifier != nil && !cfg.classifier(err)) {
+			break
+		}
+		if cfg.backoff != nil {
+			wait(ctx, cfg.backoff(attempt))
+		}
+	}
+
+	var zero R
+	return zero, lastErr
+}
+
+// wait blocks for d, returning early if ctx is cancelled.
+func wait(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,69 @@
+package batcherror
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	results, err := Run(context.Background(), items, func(ctx context.Context, idx int, item int) (int, error) {
+		if item%2 == 0 {
+			return 0, errors.New("even")
+		}
+		return item * 10, nil
+	}, WithConcurrency(2))
+
+	require.Error(t, err)
+	require.Equal(t, []int{10, 0, 30, 0, 50}, results)
+
+	m := MapIndexedErrors(err)
+	require.Len(t, m, 2)
+	require.Contains(t, m, 1)
+	require.Contains(t, m, 3)
+}
+
+func TestRunRetry(t *testing.T) {
+	var calls int32
+	items := []int{1}
+
+	results, err := Run(context.Background(), items, func(ctx context.Context, idx int, item int) (int, error) {
+		n := atomic.AddInt32(&calls, 1)
+		if n < 3 {
+			return 0, errors.New("not yet")
+		}
+		return item, nil
+	}, WithRetry(3, func(attempt int) time.Duration { return time.Millisecond }))
+
+	require.NoError(t, err)
+	require.Equal(t, []int{1}, results)
+	require.Equal(t, int32(3), calls)
+}
+
+func TestRunFailFast(t *testing.T) {
+	items := []int{1, 2, 3}
+
+	_, err := Run(context.Background(), items, func(ctx context.Context, idx int, item int) (int, error) {
+		return 0, errors.New("boom")
+	}, WithFailFast())
+
+	require.Error(t, err)
+	require.ErrorIs(t, err, ErrBatchFailed)
+}
+
+func TestRunMaxFailures(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	results, err := Run(context.Background(), items, func(ctx context.Context, idx int, item int) (int, error) {
+		return 0, errors.New("boom")
+	}, WithConcurrency(1), WithMaxFailures(2))
+
+	require.Error(t, err)
+	require.Equal(t, make([]int, 5), results)
+}